@@ -0,0 +1,68 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// SuggestedFix returns the earliest version of the report's main package
+// that fixes the vulnerabilities it describes, for a user currently on
+// current. See EarliestValidFix.
+func (r *Report) SuggestedFix(current string) string {
+	return EarliestValidFix(r.Versions, current)
+}
+
+// EarliestValidFix returns the smallest fixed version among ranges that is
+// strictly greater than current and that isn't itself covered by another,
+// still-vulnerable interval in ranges (as can happen when a module has
+// several disjoint vulnerable ranges, e.g. "< 1.0.4, >= 1.1.0, < 1.1.4").
+//
+// It returns "" if no such fix exists.
+func EarliestValidFix(ranges []VersionRange, current string) string {
+	if !strings.HasPrefix(current, "v") {
+		current = "v" + current
+	}
+
+	var fixes []string
+	for _, vr := range ranges {
+		if vr.Fixed != "" {
+			fixes = append(fixes, vr.Fixed)
+		}
+	}
+	sort.Slice(fixes, func(i, j int) bool {
+		return semver.Compare(fixes[i], fixes[j]) < 0
+	})
+
+	for _, fix := range fixes {
+		if semver.Compare(fix, current) <= 0 {
+			continue
+		}
+		if !isVulnerableAt(fix, ranges) {
+			return fix
+		}
+	}
+	return ""
+}
+
+// isVulnerableAt reports whether version v falls within any of ranges.
+func isVulnerableAt(v string, ranges []VersionRange) bool {
+	for _, vr := range ranges {
+		introduced := vr.Introduced
+		if introduced == "" {
+			introduced = "v0.0.0"
+		}
+		if semver.Compare(v, introduced) < 0 {
+			continue
+		}
+		if vr.Fixed == "" || semver.Compare(v, vr.Fixed) < 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import "testing"
+
+func TestEarliestValidFix(t *testing.T) {
+	cases := []struct {
+		name    string
+		ranges  []VersionRange
+		current string
+		want    string
+	}{
+		{
+			name:    "single range",
+			ranges:  []VersionRange{{Fixed: "v1.0.4"}},
+			current: "v1.0.0",
+			want:    "v1.0.4",
+		},
+		{
+			name:    "already fixed",
+			ranges:  []VersionRange{{Fixed: "v1.0.4"}},
+			current: "v1.0.4",
+			want:    "",
+		},
+		{
+			name: "disjoint gap: earliest fix is still valid",
+			ranges: []VersionRange{
+				{Fixed: "v1.0.4"},
+				{Introduced: "v1.1.0", Fixed: "v1.1.4"},
+			},
+			current: "v1.0.0",
+			want:    "v1.0.4",
+		},
+		{
+			name: "fix is immediately subsumed by an adjoining vulnerable range",
+			ranges: []VersionRange{
+				{Fixed: "v1.0.4"},
+				{Introduced: "v1.0.4", Fixed: "v1.0.6"},
+			},
+			current: "v1.0.0",
+			want:    "v1.0.6",
+		},
+		{
+			name: "current already inside the second disjoint range",
+			ranges: []VersionRange{
+				{Fixed: "v1.0.4"},
+				{Introduced: "v1.1.0", Fixed: "v1.1.4"},
+			},
+			current: "v1.1.2",
+			want:    "v1.1.4",
+		},
+		{
+			name:    "no fix available (open-ended range)",
+			ranges:  []VersionRange{{Introduced: "v1.1.0"}},
+			current: "v1.1.0",
+			want:    "",
+		},
+		{
+			name:    "current without v prefix is normalized",
+			ranges:  []VersionRange{{Fixed: "v1.0.4"}},
+			current: "1.0.0",
+			want:    "v1.0.4",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := EarliestValidFix(c.ranges, c.current); got != c.want {
+				t.Errorf("EarliestValidFix(%v, %q) = %q, want %q", c.ranges, c.current, got, c.want)
+			}
+		})
+	}
+}
@@ -6,13 +6,20 @@ package report
 
 import (
 	"fmt"
+	"log"
+	"sort"
 	"strings"
 
+	"golang.org/x/mod/semver"
 	"golang.org/x/vulndb/internal"
 	"golang.org/x/vulndb/internal/ghsa"
 )
 
 // GHSAToReport creates a Report struct from a given GHSA SecurityAdvisory and modulePath.
+//
+// DerivedSymbols is intentionally left unset here, as it is for
+// OSVToReport: it is computed later by govulncheck's static analysis, not
+// sourced from the advisory.
 func GHSAToReport(sa *ghsa.SecurityAdvisory, modulePath string) *Report {
 	u := sa.UpdatedAt
 	r := &Report{
@@ -38,13 +45,18 @@ func GHSAToReport(sa *ghsa.SecurityAdvisory, modulePath string) *Report {
 	}
 	r.Package = sa.Vulns[0].Package
 	r.Versions = versions(sa.Vulns[0].EarliestFixedVersion, sa.Vulns[0].VulnerableVersionRange)
+	r.Symbols = sa.Vulns[0].Symbols
 	for _, v := range sa.Vulns[1:] {
 		var a Additional
 		a.Package = v.Package
 		a.Versions = versions(v.EarliestFixedVersion, v.VulnerableVersionRange)
+		a.Symbols = v.Symbols
 		r.AdditionalPackages = append(r.AdditionalPackages, a)
 	}
 	r.Fix()
+	for _, w := range LintSymbols(r) {
+		log.Printf("%s: %s", modulePath, w)
+	}
 	return r
 }
 
@@ -52,47 +64,113 @@ func GHSAToReport(sa *ghsa.SecurityAdvisory, modulePath string) *Report {
 // fixed from a Github Security Advisory's EarliestFixedVersion and
 // VulnerableVersionRange fields, and wraps them in a []VersionRange.
 //
-// If the vulnRange cannot be parsed, or the earliestFixed and vulnRange are
-// incompatible, populate the relevant fields with a TODO for a human to handle.
+// vulnRange may describe more than one disjoint interval (e.g.
+// "< 1.0.4, >= 1.1.0, < 1.1.4"), in which case one VersionRange is emitted
+// per interval.
+//
+// If the vulnRange cannot be parsed, is unsorted or overlapping, or the
+// earliestFixed and vulnRange are incompatible, populate the relevant fields
+// with a TODO for a human to handle.
 func versions(earliestFixed, vulnRange string) []VersionRange {
-	// Don't try to be fully general here. Handle the common cases (which, as of
-	// March 2022, are the only cases), and let a person handle the others.
 	items, err := parseVulnRange(vulnRange)
 	if err != nil {
-		return []VersionRange{{
-			Introduced: fmt.Sprintf("TODO (got error %q)", err),
-		}}
+		return todoVersions("got error %q", err)
 	}
-
-	var intro, fixed string
-
-	// Most common case: a single "<" item with a version that matches earliestFixed.
-	if len(items) == 1 && items[0].op == "<" && items[0].version == earliestFixed {
-		intro = "v0.0.0"
-		fixed = "v" + earliestFixed
+	if len(items) == 0 {
+		return todoVersions("empty vuln range (earliest fixed %q)", earliestFixed)
 	}
 
-	// Two items, one >= and one <, with the latter matching earliestFixed.
-	if len(items) == 2 && items[0].op == ">=" && items[1].op == "<" && items[1].version == earliestFixed {
-		intro = "v" + items[0].version
-		fixed = "v" + earliestFixed
+	sorted := make([]vulnRangeItem, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return semver.Compare("v"+sorted[i].version, "v"+sorted[j].version) < 0
+	})
+	for i := range items {
+		if items[i] != sorted[i] {
+			return todoVersions("unsorted or overlapping vuln range %q", vulnRange)
+		}
 	}
 
-	// A single "<=" item with no fixed version.
-	if len(items) == 1 && items[0].op == "<=" && earliestFixed == "" {
-		intro = "v0.0.0"
+	var ranges []VersionRange
+	introduced := ""
+	pendingIntro := false
+	pendingFixed := false
+	for _, it := range sorted {
+		switch it.op {
+		case "=":
+			// A single pinned version isn't representable as a
+			// half-open [introduced, fixed) interval.
+			return todoVersions("vuln range %q pins an exact version", vulnRange)
+		case ">", ">=":
+			if pendingIntro {
+				return todoVersions("vuln range %q has two introduced bounds in a row", vulnRange)
+			}
+			introduced = "v" + it.version
+			pendingIntro = true
+			pendingFixed = false
+		case "<":
+			if pendingFixed {
+				return todoVersions("vuln range %q has two fixed bounds in a row", vulnRange)
+			}
+			ranges = append(ranges, VersionRange{Introduced: introduced, Fixed: "v" + it.version})
+			introduced = ""
+			pendingIntro = false
+			pendingFixed = true
+		case "<=":
+			if pendingFixed {
+				return todoVersions("vuln range %q has two fixed bounds in a row", vulnRange)
+			}
+			// "<= X" doesn't identify an exact fixed version (X itself
+			// is still vulnerable), so leave Fixed unset.
+			ranges = append(ranges, VersionRange{Introduced: introduced})
+			introduced = ""
+			pendingIntro = false
+			pendingFixed = true
+		default:
+			return todoVersions("unknown operator in vuln range %q", vulnRange)
+		}
+	}
+	if pendingIntro {
+		// A trailing ">"/">=" with no upper bound: still vulnerable at HEAD.
+		ranges = append(ranges, VersionRange{Introduced: introduced})
+	}
+	if len(ranges) == 0 {
+		return todoVersions("vuln range %q produced no intervals", vulnRange)
 	}
 
-	if intro == "" {
-		intro = fmt.Sprintf("TODO (earliest fixed %q, vuln range %q)", earliestFixed, vulnRange)
+	// Cross-check EarliestFixedVersion against the smallest Fixed we emitted;
+	// downgrade to TODO only when they truly conflict.
+	if earliestFixed != "" {
+		want := "v" + earliestFixed
+		var smallestFixed string
+		for _, vr := range ranges {
+			if vr.Fixed == "" {
+				continue
+			}
+			if smallestFixed == "" || semver.Compare(vr.Fixed, smallestFixed) < 0 {
+				smallestFixed = vr.Fixed
+			}
+		}
+		if smallestFixed == "" {
+			// GHSA asserts a fix exists, but every interval we parsed is
+			// open-ended: the two truly conflict.
+			return todoVersions("earliest fixed %q but vuln range %q has no fixed bound", earliestFixed, vulnRange)
+		}
+		if smallestFixed != want {
+			return todoVersions("earliest fixed %q does not match smallest fixed version derived from range %q", earliestFixed, vulnRange)
+		}
 	}
 
-	// Unset intro if vuln was always present.
-	if intro == "v0.0.0" {
-		intro = ""
+	// Unset the first introduced version if the vuln was always present.
+	if len(ranges) > 0 && ranges[0].Introduced == "v0.0.0" {
+		ranges[0].Introduced = ""
 	}
 
-	return []VersionRange{{Introduced: intro, Fixed: fixed}}
+	return ranges
+}
+
+func todoVersions(format string, args ...interface{}) []VersionRange {
+	return []VersionRange{{Introduced: fmt.Sprintf("TODO ("+format+")", args...)}}
 }
 
 type vulnRangeItem struct {
@@ -103,8 +181,8 @@ type vulnRangeItem struct {
 // VulnerableVersionRange field into separate items.
 func parseVulnRange(s string) ([]vulnRangeItem, error) {
 	// A GHSA vuln range is a comma-separated list of items of the form "OP VERSION"
-	// where OP is one of "<", ">", "<=" or ">=" and VERSION is a semantic
-	// version.
+	// where OP is one of "<", ">", "<=", ">=" or "=" and VERSION is a semantic
+	// version, possibly carrying prerelease/build metadata.
 	var items []vulnRangeItem
 	parts := strings.Split(s, ",")
 	for _, p := range parts {
@@ -116,7 +194,13 @@ func parseVulnRange(s string) ([]vulnRangeItem, error) {
 		if !found {
 			return nil, fmt.Errorf("invalid vuln range item %q", p)
 		}
-		items = append(items, vulnRangeItem{strings.TrimSpace(before), strings.TrimSpace(after)})
+		op, version := strings.TrimSpace(before), strings.TrimSpace(after)
+		switch op {
+		case "<", "<=", ">", ">=", "=":
+		default:
+			return nil, fmt.Errorf("invalid vuln range operator %q in item %q", op, p)
+		}
+		items = append(items, vulnRangeItem{op, version})
 	}
 	return items, nil
 }
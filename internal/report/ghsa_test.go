@@ -0,0 +1,113 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVersions(t *testing.T) {
+	cases := []struct {
+		name           string
+		earliestFixed  string
+		vulnRange      string
+		want           []VersionRange
+		wantTODOSubstr string
+	}{
+		{
+			name:          "single less-than",
+			earliestFixed: "1.0.4",
+			vulnRange:     "< 1.0.4",
+			want:          []VersionRange{{Fixed: "v1.0.4"}},
+		},
+		{
+			name:          "introduced and fixed",
+			earliestFixed: "1.0.4",
+			vulnRange:     ">= 1.0.0, < 1.0.4",
+			want:          []VersionRange{{Introduced: "v1.0.0", Fixed: "v1.0.4"}},
+		},
+		{
+			name:          "multi-range",
+			earliestFixed: "1.0.4",
+			vulnRange:     "< 1.0.4, >= 1.1.0, < 1.1.4",
+			want: []VersionRange{
+				{Fixed: "v1.0.4"},
+				{Introduced: "v1.1.0", Fixed: "v1.1.4"},
+			},
+		},
+		{
+			name:          "prerelease and build metadata preserved",
+			earliestFixed: "1.2.0-rc.1+build.5",
+			vulnRange:     ">= 1.1.0, < 1.2.0-rc.1+build.5",
+			want:          []VersionRange{{Introduced: "v1.1.0", Fixed: "v1.2.0-rc.1+build.5"}},
+		},
+		{
+			name:          "lone less-than-or-equal leaves fixed unset",
+			earliestFixed: "",
+			vulnRange:     "<= 1.0.4",
+			want:          []VersionRange{{}},
+		},
+		{
+			name:           "unsorted range falls back to TODO",
+			earliestFixed:  "1.0.4",
+			vulnRange:      ">= 1.1.0, < 1.0.4",
+			wantTODOSubstr: "unsorted or overlapping",
+		},
+		{
+			name:           "two introduced bounds in a row falls back to TODO",
+			earliestFixed:  "",
+			vulnRange:      ">= 1.0.0, >= 1.1.0",
+			wantTODOSubstr: "introduced bounds in a row",
+		},
+		{
+			name:           "two fixed bounds in a row falls back to TODO",
+			earliestFixed:  "2.0.0",
+			vulnRange:      "< 1.0.4, < 2.0.0",
+			wantTODOSubstr: "fixed bounds in a row",
+		},
+		{
+			name:           "exact version pin falls back to TODO",
+			earliestFixed:  "",
+			vulnRange:      "= 1.0.4",
+			wantTODOSubstr: "pins an exact version",
+		},
+		{
+			name:           "mismatched earliest fixed falls back to TODO",
+			earliestFixed:  "9.9.9",
+			vulnRange:      "< 1.0.4",
+			wantTODOSubstr: "does not match",
+		},
+		{
+			name:           "unparseable range falls back to TODO",
+			earliestFixed:  "",
+			vulnRange:      "garbage",
+			wantTODOSubstr: "got error",
+		},
+		{
+			name:           "earliest fixed set but range is entirely open-ended falls back to TODO",
+			earliestFixed:  "1.0.0",
+			vulnRange:      ">= 1.0.0",
+			wantTODOSubstr: "no fixed bound",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := versions(c.earliestFixed, c.vulnRange)
+			if c.wantTODOSubstr != "" {
+				if len(got) != 1 || !strings.HasPrefix(got[0].Introduced, "TODO") || !strings.Contains(got[0].Introduced, c.wantTODOSubstr) {
+					t.Fatalf("versions(%q, %q) = %v, want TODO containing %q", c.earliestFixed, c.vulnRange, got, c.wantTODOSubstr)
+				}
+				return
+			}
+			if diff := cmp.Diff(c.want, got); diff != "" {
+				t.Errorf("versions(%q, %q) mismatch (-want +got):\n%s", c.earliestFixed, c.vulnRange, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import "strings"
+
+// LintSymbols returns lint warnings for a report that affects the standard
+// library or an x/ repo but lists no symbols, on any of its packages.
+// Symbols are what let govulncheck narrow its call-graph analysis, so
+// stdlib/x-repo reports are expected to carry them.
+//
+// It's called by GHSAToReport and OSVToReport so an operator running an
+// import sees the warning immediately, rather than only at publish time.
+func LintSymbols(r *Report) []string {
+	if !isStdOrXModule(r.Module) {
+		return nil
+	}
+
+	var warnings []string
+	if r.Package != "" && len(r.Symbols) == 0 {
+		warnings = append(warnings, "no symbols found for package "+r.Package)
+	}
+	for _, a := range r.AdditionalPackages {
+		if len(a.Symbols) == 0 {
+			warnings = append(warnings, "no symbols found for package "+a.Package)
+		}
+	}
+	return warnings
+}
+
+// isStdOrXModule reports whether module is the standard library, the
+// toolchain, or a golang.org/x/ repo.
+func isStdOrXModule(module string) bool {
+	return module == "std" || module == "cmd" || strings.HasPrefix(module, "golang.org/x/")
+}
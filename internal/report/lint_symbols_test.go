@@ -0,0 +1,60 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import "testing"
+
+func TestLintSymbols(t *testing.T) {
+	cases := []struct {
+		name string
+		r    *Report
+		want []string
+	}{
+		{
+			name: "std module, main package missing symbols",
+			r:    &Report{Module: "std", Package: "net/http"},
+			want: []string{"no symbols found for package net/http"},
+		},
+		{
+			name: "std module, main package has symbols",
+			r:    &Report{Module: "std", Package: "net/http", Symbols: []string{"Get"}},
+			want: nil,
+		},
+		{
+			name: "x repo, additional package missing symbols",
+			r: &Report{
+				Module:             "golang.org/x/text",
+				Package:            "golang.org/x/text/language",
+				Symbols:            []string{"Parse"},
+				AdditionalPackages: []Additional{{Package: "golang.org/x/text/encoding"}},
+			},
+			want: []string{"no symbols found for package golang.org/x/text/encoding"},
+		},
+		{
+			name: "cmd module missing symbols",
+			r:    &Report{Module: "cmd", Package: "cmd/go"},
+			want: []string{"no symbols found for package cmd/go"},
+		},
+		{
+			name: "non-std module is a no-op regardless of symbols",
+			r:    &Report{Module: "example.com/mod", Package: "example.com/mod/pkg"},
+			want: nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := LintSymbols(c.r)
+			if len(got) != len(c.want) {
+				t.Fatalf("LintSymbols() = %v, want %v", got, c.want)
+			}
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Errorf("LintSymbols()[%d] = %q, want %q", i, got[i], c.want[i])
+				}
+			}
+		})
+	}
+}
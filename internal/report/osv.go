@@ -0,0 +1,143 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"log"
+	"strings"
+
+	"golang.org/x/vulndb/internal/osv"
+)
+
+// OSVToReport creates a Report struct from a given osv.Entry and modulePath.
+//
+// The Report's Module is modulePath unless the first affected package's
+// import path identifies it as part of the standard library or the
+// toolchain, in which case the inferred module ("std" or "cmd") takes
+// precedence. The module inferred for any further affected package is not
+// recorded.
+func OSVToReport(e *osv.Entry, modulePath string) *Report {
+	m := e.Modified
+	r := &Report{
+		Description:  e.Details,
+		Published:    e.Published,
+		LastModified: &m,
+	}
+	for _, a := range e.Aliases {
+		switch {
+		case strings.HasPrefix(a, "CVE-"):
+			r.CVEs = append(r.CVEs, a)
+		case strings.HasPrefix(a, "GHSA-"):
+			r.GHSAs = append(r.GHSAs, a)
+		}
+	}
+	for _, ref := range e.References {
+		r.Links.Context = append(r.Links.Context, ref.URL)
+	}
+	if len(e.Affected) == 0 {
+		return r
+	}
+
+	pkg, mod, versions, symbols := osvAffected(e.Affected[0], modulePath)
+	r.Module = mod
+	r.Package = pkg
+	r.Versions = versions
+	r.Symbols = symbols
+	for _, a := range e.Affected[1:] {
+		pkg, _, versions, symbols := osvAffected(a, modulePath)
+		r.AdditionalPackages = append(r.AdditionalPackages, Additional{
+			Package:  pkg,
+			Versions: versions,
+			Symbols:  symbols,
+		})
+	}
+	r.Fix()
+	for _, w := range LintSymbols(r) {
+		log.Printf("%s: %s", modulePath, w)
+	}
+	return r
+}
+
+// osvAffected extracts the package path, module, version ranges and symbols
+// from a single osv.Affected entry. The package path and symbols come from
+// ecosystem_specific.imports when present, falling back to the affected
+// package name and no symbols.
+//
+// DerivedSymbols is intentionally left unset here: it is computed later by
+// govulncheck's static analysis, not sourced from the advisory.
+func osvAffected(a osv.Affected, modulePath string) (pkg, mod string, versions []VersionRange, symbols []string) {
+	pkg = a.Package.Name
+	if len(a.EcosystemSpecific.Imports) > 0 {
+		pkg = a.EcosystemSpecific.Imports[0].Path
+		symbols = a.EcosystemSpecific.Imports[0].Symbols
+	}
+
+	mod = modulePath
+	switch {
+	case strings.HasPrefix(pkg, "cmd/"):
+		mod = "cmd"
+	case isStdPackage(pkg):
+		mod = "std"
+	}
+
+	for _, r := range a.Ranges {
+		if r.Type != osv.RangeTypeSemver {
+			continue
+		}
+		versions = append(versions, osvEventsToVersions(r.Events)...)
+	}
+	return pkg, mod, versions, symbols
+}
+
+// osvEventsToVersions converts a sequence of introduced/fixed events, as
+// found in an osv.Range, into a []VersionRange.
+func osvEventsToVersions(events []osv.RangeEvent) []VersionRange {
+	var out []VersionRange
+	var cur VersionRange
+	pending := false
+	for _, ev := range events {
+		switch {
+		case ev.Introduced != "":
+			cur = VersionRange{Introduced: osvVersion(ev.Introduced)}
+			pending = true
+		case ev.Fixed != "":
+			cur.Fixed = osvVersion(ev.Fixed)
+			out = append(out, cur)
+			cur = VersionRange{}
+			pending = false
+		}
+	}
+	if pending {
+		// A trailing "introduced" with no following "fixed": the
+		// standard shape for a vulnerability that is still unfixed at
+		// HEAD. Emit it as an open-ended range instead of dropping it.
+		out = append(out, cur)
+	}
+	return out
+}
+
+// osvVersion converts an OSV version string to the "vX.Y.Z" form used in
+// Reports. OSV uses "0" to mean "the beginning of time", which we represent
+// as an unset Introduced field.
+func osvVersion(v string) string {
+	if v == "0" {
+		return ""
+	}
+	return "v" + v
+}
+
+// isStdPackage reports whether pkg looks like a standard library import
+// path, i.e. it is non-empty, not under cmd/, and its first path element
+// contains no dot.
+func isStdPackage(pkg string) bool {
+	if pkg == "" || strings.HasPrefix(pkg, "cmd/") {
+		return false
+	}
+	first := pkg
+	if i := strings.Index(pkg, "/"); i >= 0 {
+		first = pkg[:i]
+	}
+	return !strings.Contains(first, ".")
+}
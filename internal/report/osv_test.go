@@ -0,0 +1,79 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/vulndb/internal/osv"
+)
+
+func TestOsvEventsToVersions(t *testing.T) {
+	cases := []struct {
+		name   string
+		events []osv.RangeEvent
+		want   []VersionRange
+	}{
+		{
+			name:   "introduced at the beginning, fixed",
+			events: []osv.RangeEvent{{Introduced: "0"}, {Fixed: "1.0.4"}},
+			want:   []VersionRange{{Fixed: "v1.0.4"}},
+		},
+		{
+			name:   "still unfixed at HEAD",
+			events: []osv.RangeEvent{{Introduced: "0"}},
+			want:   []VersionRange{{}},
+		},
+		{
+			name:   "introduced after the beginning, still unfixed at HEAD",
+			events: []osv.RangeEvent{{Introduced: "1.1.0"}},
+			want:   []VersionRange{{Introduced: "v1.1.0"}},
+		},
+		{
+			name: "multiple disjoint ranges",
+			events: []osv.RangeEvent{
+				{Introduced: "0"}, {Fixed: "1.0.4"},
+				{Introduced: "1.1.0"}, {Fixed: "1.1.4"},
+			},
+			want: []VersionRange{
+				{Fixed: "v1.0.4"},
+				{Introduced: "v1.1.0", Fixed: "v1.1.4"},
+			},
+		},
+		{
+			name:   "no events",
+			events: nil,
+			want:   nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := osvEventsToVersions(c.events)
+			if diff := cmp.Diff(c.want, got); diff != "" {
+				t.Errorf("osvEventsToVersions(%v) mismatch (-want +got):\n%s", c.events, diff)
+			}
+		})
+	}
+}
+
+func TestIsStdPackage(t *testing.T) {
+	cases := []struct {
+		pkg  string
+		want bool
+	}{
+		{"net/http", true},
+		{"fmt", true},
+		{"cmd/go", false},
+		{"golang.org/x/vulndb/internal", false},
+		{"", false},
+	}
+	for _, c := range cases {
+		if got := isStdPackage(c.pkg); got != c.want {
+			t.Errorf("isStdPackage(%q) = %v, want %v", c.pkg, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"golang.org/x/vulndb/internal/osv"
+)
+
+// ToOSV converts a Report into an osv.Entry, the inverse of OSVToReport.
+// Each affected package becomes its own osv.Affected, with the package's
+// Symbols round-tripped into ecosystem_specific.imports[].symbols.
+func (r *Report) ToOSV() *osv.Entry {
+	e := &osv.Entry{
+		Published: r.Published,
+		Details:   r.Description,
+	}
+	if r.LastModified != nil {
+		e.Modified = *r.LastModified
+	}
+	e.Aliases = append(e.Aliases, r.CVEs...)
+	e.Aliases = append(e.Aliases, r.GHSAs...)
+	e.References = append(e.References, osvReferences(r.Links)...)
+
+	if r.Package != "" {
+		e.Affected = append(e.Affected, reportPackageToAffected(r.Module, r.Package, r.Versions, r.Symbols))
+	}
+	for _, a := range r.AdditionalPackages {
+		e.Affected = append(e.Affected, reportPackageToAffected(r.Module, a.Package, a.Versions, a.Symbols))
+	}
+	return e
+}
+
+// reportPackageToAffected builds a single osv.Affected for one package of a
+// Report, carrying its version ranges and symbols.
+func reportPackageToAffected(modulePath, pkg string, versions []VersionRange, symbols []string) osv.Affected {
+	return osv.Affected{
+		Package: osv.Package{Name: modulePath, Ecosystem: osv.GoEcosystem},
+		Ranges: []osv.AffectsRange{{
+			Type:   osv.RangeTypeSemver,
+			Events: versionsToOSVEvents(versions),
+		}},
+		EcosystemSpecific: osv.EcosystemSpecific{
+			Imports: []osv.EcosystemSpecificImport{{
+				Path:    pkg,
+				Symbols: symbols,
+			}},
+		},
+	}
+}
+
+// versionsToOSVEvents converts a []VersionRange back into the
+// introduced/fixed event sequence used by osv.AffectsRange, the inverse of
+// osvEventsToVersions.
+func versionsToOSVEvents(versions []VersionRange) []osv.RangeEvent {
+	var events []osv.RangeEvent
+	for _, v := range versions {
+		introduced := "0"
+		if v.Introduced != "" {
+			introduced = v.Introduced[1:] // strip the "v" prefix
+		}
+		events = append(events, osv.RangeEvent{Introduced: introduced})
+		if v.Fixed != "" {
+			events = append(events, osv.RangeEvent{Fixed: v.Fixed[1:]})
+		}
+	}
+	return events
+}
+
+// osvReferences converts a Report's Links into OSV references, all tagged
+// as "WEB" since Links doesn't distinguish reference types.
+func osvReferences(l Links) []osv.Reference {
+	var refs []osv.Reference
+	for _, url := range l.Context {
+		refs = append(refs, osv.Reference{Type: osv.ReferenceWeb, URL: url})
+	}
+	return refs
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/vulndb/internal/osv"
+)
+
+// TestOSVReportRoundTrip checks that converting an osv.Entry to a Report
+// and back with ToOSV reproduces the same version events and symbols.
+func TestOSVReportRoundTrip(t *testing.T) {
+	published := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	modified := time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	entry := &osv.Entry{
+		Published: published,
+		Modified:  modified,
+		Details:   "an example vulnerability",
+		Aliases:   []string{"CVE-2022-1234", "GHSA-aaaa-bbbb-cccc"},
+		Affected: []osv.Affected{{
+			Package: osv.Package{Name: "example.com/mod", Ecosystem: osv.GoEcosystem},
+			Ranges: []osv.AffectsRange{{
+				Type:   osv.RangeTypeSemver,
+				Events: []osv.RangeEvent{{Introduced: "0"}, {Fixed: "1.0.4"}},
+			}},
+			EcosystemSpecific: osv.EcosystemSpecific{
+				Imports: []osv.EcosystemSpecificImport{{
+					Path:    "example.com/mod/pkg",
+					Symbols: []string{"F", "T.M"},
+				}},
+			},
+		}},
+		References: []osv.Reference{{Type: osv.ReferenceWeb, URL: "https://example.com/advisory"}},
+	}
+
+	r := OSVToReport(entry, "example.com/mod")
+	back := r.ToOSV()
+
+	if len(back.Affected) != 1 {
+		t.Fatalf("ToOSV() produced %d Affected entries, want 1", len(back.Affected))
+	}
+	got := back.Affected[0]
+
+	if diff := cmp.Diff(entry.Affected[0].Ranges[0].Events, got.Ranges[0].Events); diff != "" {
+		t.Errorf("round-tripped events mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(entry.Affected[0].EcosystemSpecific.Imports[0].Symbols, got.EcosystemSpecific.Imports[0].Symbols); diff != "" {
+		t.Errorf("round-tripped symbols mismatch (-want +got):\n%s", diff)
+	}
+	if got.EcosystemSpecific.Imports[0].Path != "example.com/mod/pkg" {
+		t.Errorf("round-tripped package path = %q, want %q", got.EcosystemSpecific.Imports[0].Path, "example.com/mod/pkg")
+	}
+}
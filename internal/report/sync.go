@@ -0,0 +1,119 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/vulndb/internal/ghsa"
+)
+
+// FieldDiff describes a single field-level change between an on-disk Report
+// and the Report derived from a freshly-fetched GHSA.
+type FieldDiff struct {
+	Field    string
+	Old, New string
+}
+
+// Diff describes the field-level changes between an on-disk Report and an
+// updated GHSA advisory for the same vulnerability, for a human to review
+// and accept.
+type Diff struct {
+	// ID is the GHSA or CVE id existing was keyed by.
+	ID     string
+	Fields []FieldDiff
+}
+
+// ghsaLister is the subset of ghsa.Client that SyncGHSAs needs, kept narrow
+// so it can be faked in tests.
+type ghsaLister interface {
+	GoAdvisories(ctx context.Context) ([]*ghsa.SecurityAdvisory, error)
+}
+
+// SyncGHSAs lists all Go-ecosystem GHSAs known to client, converts each to a
+// Report, and reconciles the result against existing, a map of on-disk
+// Reports keyed by GHSA or CVE id.
+//
+// Advisories with no corresponding entry in existing are returned as fresh
+// draft Reports. Advisories whose derived Report differs from the on-disk
+// one in any field that diffReports compares produce a Diff describing the
+// changed fields. Advisories that match their on-disk Report in all of those
+// fields are skipped; differences in other fields (such as Links or
+// Published) are not reported.
+func SyncGHSAs(ctx context.Context, client ghsaLister, existing map[string]*Report) ([]*Report, []Diff, error) {
+	advisories, err := client.GoAdvisories(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing Go-ecosystem GHSAs: %w", err)
+	}
+
+	var fresh []*Report
+	var diffs []Diff
+	for _, sa := range advisories {
+		id := ghsaKey(sa)
+		if id == "" {
+			continue
+		}
+		old, ok := existing[id]
+		if !ok {
+			fresh = append(fresh, GHSAToReport(sa, ""))
+			continue
+		}
+		// The module path isn't derivable from the advisory; carry it
+		// over from the on-disk report so it doesn't show up as a
+		// spurious diff.
+		r := GHSAToReport(sa, old.Module)
+		if d := diffReports(id, old, r); len(d.Fields) > 0 {
+			diffs = append(diffs, d)
+		}
+	}
+	return fresh, diffs, nil
+}
+
+// ghsaKey returns the identifier existing Reports are keyed by: the first
+// GHSA id if present, otherwise the first CVE id.
+func ghsaKey(sa *ghsa.SecurityAdvisory) string {
+	var cve string
+	for _, id := range sa.Identifiers {
+		switch id.Type {
+		case "GHSA":
+			return id.Value
+		case "CVE":
+			if cve == "" {
+				cve = id.Value
+			}
+		}
+	}
+	return cve
+}
+
+// diffReports compares old and updated, the on-disk and freshly-derived
+// Reports for the advisory keyed by id, and returns the fields that changed.
+func diffReports(id string, old, updated *Report) Diff {
+	d := Diff{ID: id}
+	addIfChanged := func(field, oldVal, newVal string) {
+		if oldVal != newVal {
+			d.Fields = append(d.Fields, FieldDiff{Field: field, Old: oldVal, New: newVal})
+		}
+	}
+
+	var oldModified, newModified string
+	if old.LastModified != nil {
+		oldModified = old.LastModified.String()
+	}
+	if updated.LastModified != nil {
+		newModified = updated.LastModified.String()
+	}
+	addIfChanged("last_modified", oldModified, newModified)
+	addIfChanged("package", old.Package, updated.Package)
+	addIfChanged("versions", fmt.Sprintf("%v", old.Versions), fmt.Sprintf("%v", updated.Versions))
+	addIfChanged("symbols", fmt.Sprintf("%v", old.Symbols), fmt.Sprintf("%v", updated.Symbols))
+	addIfChanged("additional_packages", fmt.Sprintf("%v", old.AdditionalPackages), fmt.Sprintf("%v", updated.AdditionalPackages))
+	addIfChanged("cves", fmt.Sprintf("%v", old.CVEs), fmt.Sprintf("%v", updated.CVEs))
+	addIfChanged("ghsas", fmt.Sprintf("%v", old.GHSAs), fmt.Sprintf("%v", updated.GHSAs))
+	addIfChanged("description", old.Description, updated.Description)
+
+	return d
+}
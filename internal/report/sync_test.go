@@ -0,0 +1,115 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/vulndb/internal/ghsa"
+)
+
+type fakeGHSALister struct {
+	advisories []*ghsa.SecurityAdvisory
+}
+
+func (f *fakeGHSALister) GoAdvisories(ctx context.Context) ([]*ghsa.SecurityAdvisory, error) {
+	return f.advisories, nil
+}
+
+func newTestAdvisory(ghsaID, pkg, vulnRange, fixed string, updatedAt time.Time) *ghsa.SecurityAdvisory {
+	return &ghsa.SecurityAdvisory{
+		UpdatedAt:   updatedAt,
+		Description: "an advisory",
+		Identifiers: []ghsa.Identifier{{Type: "GHSA", Value: ghsaID}},
+		Vulns: []ghsa.Vuln{{
+			Package:                pkg,
+			EarliestFixedVersion:   fixed,
+			VulnerableVersionRange: vulnRange,
+		}},
+	}
+}
+
+func TestSyncGHSAs(t *testing.T) {
+	t0 := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	newAdvisory := newTestAdvisory("GHSA-new1-new1-new1", "example.com/new", "< 1.0.0", "1.0.0", t0)
+	changedAdvisory := newTestAdvisory("GHSA-chg1-chg1-chg1", "example.com/changed", "< 1.0.4", "1.0.4", t1)
+	unchangedAdvisory := newTestAdvisory("GHSA-unch1-unch1-unch1", "example.com/unchanged", "< 1.0.4", "1.0.4", t0)
+
+	existing := map[string]*Report{
+		"GHSA-chg1-chg1-chg1":    GHSAToReport(newTestAdvisory("GHSA-chg1-chg1-chg1", "example.com/changed", "< 1.0.0", "1.0.0", t0), "example.com/changed"),
+		"GHSA-unch1-unch1-unch1": GHSAToReport(unchangedAdvisory, "example.com/unchanged"),
+	}
+
+	lister := &fakeGHSALister{advisories: []*ghsa.SecurityAdvisory{newAdvisory, changedAdvisory, unchangedAdvisory}}
+
+	fresh, diffs, err := SyncGHSAs(context.Background(), lister, existing)
+	if err != nil {
+		t.Fatalf("SyncGHSAs returned error: %v", err)
+	}
+
+	if len(fresh) != 1 || fresh[0].GHSAs[0] != "GHSA-new1-new1-new1" {
+		t.Errorf("fresh = %v, want a single draft for the new advisory", fresh)
+	}
+
+	if len(diffs) != 1 {
+		t.Fatalf("diffs = %v, want exactly one diff for the changed advisory", diffs)
+	}
+	d := diffs[0]
+	if d.ID != "GHSA-chg1-chg1-chg1" {
+		t.Errorf("diff ID = %q, want %q", d.ID, "GHSA-chg1-chg1-chg1")
+	}
+	var sawVersions, sawLastModified bool
+	for _, f := range d.Fields {
+		switch f.Field {
+		case "versions":
+			sawVersions = true
+		case "last_modified":
+			sawLastModified = true
+		}
+	}
+	if !sawVersions || !sawLastModified {
+		t.Errorf("diff.Fields = %v, want changes in versions and last_modified", d.Fields)
+	}
+}
+
+func TestGhsaKey(t *testing.T) {
+	cases := []struct {
+		name string
+		sa   *ghsa.SecurityAdvisory
+		want string
+	}{
+		{
+			name: "GHSA id present",
+			sa: &ghsa.SecurityAdvisory{Identifiers: []ghsa.Identifier{
+				{Type: "CVE", Value: "CVE-2022-1234"},
+				{Type: "GHSA", Value: "GHSA-aaaa-bbbb-cccc"},
+			}},
+			want: "GHSA-aaaa-bbbb-cccc",
+		},
+		{
+			name: "falls back to CVE id",
+			sa: &ghsa.SecurityAdvisory{Identifiers: []ghsa.Identifier{
+				{Type: "CVE", Value: "CVE-2022-1234"},
+			}},
+			want: "CVE-2022-1234",
+		},
+		{
+			name: "no identifiers",
+			sa:   &ghsa.SecurityAdvisory{},
+			want: "",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ghsaKey(c.sa); got != c.want {
+				t.Errorf("ghsaKey(%v) = %q, want %q", c.sa, got, c.want)
+			}
+		})
+	}
+}